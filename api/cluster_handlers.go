@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ClusterHandler exposes this node's view of scheduler leadership, mainly
+// for operators checking which replica is currently active.
+type ClusterHandler struct {
+	isLeader func() bool
+}
+
+func NewClusterHandler(isLeader func() bool) *ClusterHandler {
+	return &ClusterHandler{isLeader: isLeader}
+}
+
+func (h *ClusterHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/cluster/leader/", h.GetLeaderStatus).Methods("GET")
+}
+
+func (h *ClusterHandler) GetLeaderStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, struct {
+		IsLeader bool `json:"is_leader"`
+	}{IsLeader: h.isLeader()})
+}
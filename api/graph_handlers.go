@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/nextiva/nextkala/job"
+)
+
+// GraphHandler serves the resolved DAG around one job at
+// /api/v1/job/{id}/graph/, so users can see a multi-step pipeline's
+// parents, children, and their last-run status without externalizing
+// orchestration.
+type GraphHandler struct {
+	jobDB job.JobDB
+}
+
+func NewGraphHandler(jobDB job.JobDB) *GraphHandler {
+	return &GraphHandler{jobDB: jobDB}
+}
+
+func (h *GraphHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/job/{id}/graph/", h.GetGraph).Methods("GET")
+}
+
+func (h *GraphHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	graph, err := job.ResolveGraph(h.jobDB, jobID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, graph)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/nextiva/nextkala/job"
+)
+
+// InspectorHandler exposes JobDB's per-state run buckets at
+// /api/v1/inspect/{state}/, modeled on task-queue introspection tools:
+// operators can page through what's pending, active, retrying, or dead.
+type InspectorHandler struct {
+	jobDB job.JobDB
+}
+
+func NewInspectorHandler(jobDB job.JobDB) *InspectorHandler {
+	return &InspectorHandler{jobDB: jobDB}
+}
+
+func (h *InspectorHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/inspect/{state}/", h.List).Methods("GET")
+	r.HandleFunc("/api/v1/inspect/retry/", h.DeleteAllRetry).Methods("DELETE")
+	r.HandleFunc("/api/v1/job/{id}/archive/", h.ArchiveJob).Methods("POST")
+	r.HandleFunc("/api/v1/job/{id}/run/", h.RunJob).Methods("POST")
+}
+
+func (h *InspectorHandler) List(w http.ResponseWriter, r *http.Request) {
+	state := mux.Vars(r)["state"]
+
+	qname := r.URL.Query().Get("queue")
+	pageSize := queryInt(r, "page_size", 20)
+	page := queryInt(r, "page", 0)
+
+	var (
+		runs []*job.JobStat
+		err  error
+	)
+	switch job.RunState(state) {
+	case job.StatePending:
+		runs, err = h.jobDB.ListScheduled(qname, pageSize, page)
+	case job.StateActive:
+		runs, err = h.jobDB.ListActive(qname, pageSize, page)
+	case job.StateRetry:
+		runs, err = h.jobDB.ListRetry(qname, pageSize, page)
+	case job.StateDead:
+		runs, err = h.jobDB.ListDead(qname, pageSize, page)
+	default:
+		http.Error(w, "unknown state: "+state, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, runs)
+}
+
+func (h *InspectorHandler) DeleteAllRetry(w http.ResponseWriter, r *http.Request) {
+	if err := h.jobDB.DeleteAllRetryJobs(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InspectorHandler) ArchiveJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.jobDB.ArchiveJob(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InspectorHandler) RunJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.jobDB.RunJob(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/nextiva/nextkala/job"
+)
+
+// HistoryHandler serves the job-history subsystem: GET /api/v1/job/{id}/history/
+// and POST /api/v1/job/{id}/rollback/{version}/.
+type HistoryHandler struct {
+	jobDB job.JobDB
+	cache job.JobCache
+}
+
+func NewHistoryHandler(jobDB job.JobDB, cache job.JobCache) *HistoryHandler {
+	return &HistoryHandler{jobDB: jobDB, cache: cache}
+}
+
+func (h *HistoryHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/job/{id}/history/", h.GetHistory).Methods("GET")
+	r.HandleFunc("/api/v1/job/{id}/history/{version}/", h.GetVersion).Methods("GET")
+	r.HandleFunc("/api/v1/job/{id}/rollback/{version}/", h.Rollback).Methods("POST")
+}
+
+func (h *HistoryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	versions, err := h.jobDB.GetHistory(jobID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, versions)
+}
+
+func (h *HistoryHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["id"]
+
+	version, err := strconv.ParseUint(vars["version"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	jobVersion, err := h.jobDB.GetVersion(jobID, version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, jobVersion)
+}
+
+func (h *HistoryHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["id"]
+
+	version, err := strconv.ParseUint(vars["version"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.jobDB.Rollback(jobID, version); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Rollback only rewrote the backing store; re-register the restored
+	// job with the live cache so the scheduler picks up its restored
+	// schedule, dependencies, and disabled flag without a process restart.
+	restored, err := h.jobDB.Get(jobID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := restored.Init(h.cache); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/nextiva/nextkala/job"
+)
+
+func TestRollbackReregistersWithCache(t *testing.T) {
+	db := job.NewMemoryDB()
+	cache := job.NewLockFreeJobCache(db)
+
+	j := job.NewJob("test", "true", "owner@example.com")
+	if err := j.Init(cache); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if err := db.Save(j); err != nil { // version 1: Disabled == false
+		t.Fatalf("save: %v", err)
+	}
+
+	j.Disabled = true
+	if err := db.Save(j); err != nil { // version 2: Disabled == true
+		t.Fatalf("save disabled: %v", err)
+	}
+
+	if cached, _ := cache.Get(j.Id); !cached.Disabled {
+		t.Fatal("expected cache to reflect the disabled job before rollback")
+	}
+
+	router := mux.NewRouter()
+	NewHistoryHandler(db, cache).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/job/"+j.Id+"/rollback/1/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	cached, err := cache.Get(j.Id)
+	if err != nil {
+		t.Fatalf("get from cache: %v", err)
+	}
+	if cached.Disabled {
+		t.Fatal("expected rollback to re-enable the job in the live cache, not just the backing store")
+	}
+}
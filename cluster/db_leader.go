@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LeaseStore is the storage contract a DB-backed SchedulerLeader needs: a
+// single scheduler_leader row holding (node_id, expires_at), updated via a
+// conditional UPDATE so only one node can hold it at a time.
+type LeaseStore interface {
+	// AcquireLease attempts to become, or renew as, the lease holder for
+	// ttl. It returns true if nodeID holds the lease afterward.
+	AcquireLease(nodeID string, ttl time.Duration) (bool, error)
+	// ReleaseLease gives up the lease if it is currently held by nodeID.
+	ReleaseLease(nodeID string) error
+}
+
+// DBLeader is a SchedulerLeader backed by a LeaseStore (etcd/Consul
+// sessions and a DB-backed lease row all satisfy this shape). It polls for
+// the lease and, once acquired, renews it at half its TTL; a failed
+// renewal is treated as lost leadership.
+type DBLeader struct {
+	store  LeaseStore
+	nodeID string
+	ttl    time.Duration
+	poll   time.Duration
+
+	mu      sync.Mutex
+	session *leaseSession
+}
+
+// leaseSession is the lost channel for one held-leadership period, paired
+// with the sync.Once that guards it: renew and Resign can both decide to
+// close it around the same time, and closing an already-closed channel
+// panics, so only one of them may actually do it.
+type leaseSession struct {
+	lost chan struct{}
+	once sync.Once
+}
+
+func (s *leaseSession) close() {
+	s.once.Do(func() { close(s.lost) })
+}
+
+func NewDBLeader(store LeaseStore, nodeID string, ttl time.Duration) *DBLeader {
+	return &DBLeader{
+		store:  store,
+		nodeID: nodeID,
+		ttl:    ttl,
+		poll:   ttl / 4,
+	}
+}
+
+func (l *DBLeader) Acquire(ctx context.Context) (<-chan struct{}, error) {
+	ticker := time.NewTicker(l.poll)
+	defer ticker.Stop()
+
+	for {
+		held, err := l.store.AcquireLease(l.nodeID, l.ttl)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	session := &leaseSession{lost: make(chan struct{})}
+	l.mu.Lock()
+	l.session = session
+	l.mu.Unlock()
+
+	go l.renew(ctx, session)
+
+	return session.lost, nil
+}
+
+func (l *DBLeader) renew(ctx context.Context, session *leaseSession) {
+	ticker := time.NewTicker(l.ttl / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			session.close()
+			return
+		case <-session.lost:
+			// Resign closed this session; stop renewing so the lease it
+			// released isn't taken straight back.
+			return
+		case <-ticker.C:
+			held, err := l.store.AcquireLease(l.nodeID, l.ttl)
+			if err != nil || !held {
+				session.close()
+				return
+			}
+		}
+	}
+}
+
+func (l *DBLeader) Resign() error {
+	l.mu.Lock()
+	session := l.session
+	l.mu.Unlock()
+
+	if session != nil {
+		session.close()
+	}
+	return l.store.ReleaseLease(l.nodeID)
+}
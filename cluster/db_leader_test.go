@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLeaseStore is a minimal in-memory LeaseStore: whichever node last
+// called AcquireLease within ttl of the last call holds the lease.
+type fakeLeaseStore struct {
+	mu        sync.Mutex
+	holder    string
+	expiresAt time.Time
+}
+
+func (f *fakeLeaseStore) AcquireLease(nodeID string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.holder != "" && f.holder != nodeID && now.Before(f.expiresAt) {
+		return false, nil
+	}
+	f.holder = nodeID
+	f.expiresAt = now.Add(ttl)
+	return true, nil
+}
+
+func (f *fakeLeaseStore) ReleaseLease(nodeID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.holder == nodeID {
+		f.holder = ""
+	}
+	return nil
+}
+
+func TestDBLeaderAcquiresAndRenews(t *testing.T) {
+	store := &fakeLeaseStore{}
+	l := NewDBLeader(store, "node-a", 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	lost, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	select {
+	case <-lost:
+		t.Fatal("expected leadership to be retained across a renewal cycle")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if err := l.Resign(); err != nil {
+		t.Fatalf("resign: %v", err)
+	}
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("expected Resign to close the lost channel")
+	}
+}
+
+func TestDBLeaderLosesLeadershipWhenLeaseIsTakenOver(t *testing.T) {
+	store := &fakeLeaseStore{}
+	l := NewDBLeader(store, "node-a", 60*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	lost, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// Simulate another node stealing the lease once it expires.
+	time.Sleep(80 * time.Millisecond)
+	store.mu.Lock()
+	store.holder = "node-b"
+	store.expiresAt = time.Now().Add(time.Minute)
+	store.mu.Unlock()
+
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("expected losing the lease to another node to close the lost channel")
+	}
+}
@@ -0,0 +1,61 @@
+// Package cluster provides the leader-election primitives nextkala uses to
+// run exactly one active Scheduler in an HA deployment while every node
+// keeps running job Workers.
+package cluster
+
+import (
+	"context"
+	"sync"
+)
+
+// SchedulerLeader decides which node's Scheduler is allowed to dispatch
+// due jobs. Implementations range from a single-node no-op to a
+// distributed lease backed by etcd, Consul, or a database row.
+type SchedulerLeader interface {
+	// Acquire blocks until this node becomes leader or ctx is canceled. On
+	// success it returns a channel that is closed the moment leadership is
+	// lost, so the caller can stop its scheduling goroutines.
+	Acquire(ctx context.Context) (<-chan struct{}, error)
+	// Resign voluntarily gives up leadership.
+	Resign() error
+}
+
+// SingleNodeLeader is the default SchedulerLeader: it wins immediately and
+// never loses leadership except via an explicit Resign. It's appropriate
+// for single-replica deployments where no coordination is needed.
+type SingleNodeLeader struct {
+	mu   sync.Mutex
+	lost chan struct{}
+}
+
+func NewSingleNodeLeader() *SingleNodeLeader {
+	return &SingleNodeLeader{lost: make(chan struct{})}
+}
+
+// Acquire re-arms a fresh lost channel if a prior Resign closed the last
+// one, so a node can keep regaining leadership across repeated
+// Acquire/Resign cycles instead of spinning on an already-closed channel.
+func (l *SingleNodeLeader) Acquire(ctx context.Context) (<-chan struct{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-l.lost:
+		l.lost = make(chan struct{})
+	default:
+	}
+	return l.lost, nil
+}
+
+func (l *SingleNodeLeader) Resign() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-l.lost:
+		// already resigned
+	default:
+		close(l.lost)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSingleNodeLeaderReacquiresAfterResign(t *testing.T) {
+	l := NewSingleNodeLeader()
+
+	first, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	if err := l.Resign(); err != nil {
+		t.Fatalf("resign: %v", err)
+	}
+	select {
+	case <-first:
+	case <-time.After(time.Second):
+		t.Fatal("expected the channel returned by the first Acquire to close on Resign")
+	}
+
+	second, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("second acquire: %v", err)
+	}
+	select {
+	case <-second:
+		t.Fatal("expected the channel from a fresh Acquire after Resign to still be open")
+	default:
+	}
+}
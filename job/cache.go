@@ -0,0 +1,87 @@
+package job
+
+import "sync"
+
+// JobCache is the in-memory view of all known jobs that the scheduler reads
+// from on every tick. Reads are served out of memory so scheduling never
+// blocks on the backend. In LockFreeJobCache, Delete also removes the job
+// from the backing JobDB, while Set only updates the in-memory view.
+type JobCache interface {
+	Get(id string) (*Job, error)
+	GetAll() map[string]*Job
+	Set(job *Job)
+	Delete(id string) error
+}
+
+// LockFreeJobCache is the default JobCache. "Lock-free" refers to the
+// scheduler's hot path: reads take a shared RLock rather than contending
+// with a global mutex, so a busy tick doesn't stall job submission.
+type LockFreeJobCache struct {
+	jobDB JobDB
+	jobs  map[string]*Job
+	lock  sync.RWMutex
+}
+
+// NewLockFreeJobCache builds a cache backed by jobDB. Callers are
+// responsible for loading existing jobs from jobDB into the cache (see
+// Start) before relying on it for scheduling.
+func NewLockFreeJobCache(jobDB JobDB) *LockFreeJobCache {
+	return &LockFreeJobCache{
+		jobDB: jobDB,
+		jobs:  map[string]*Job{},
+	}
+}
+
+// Start loads every job currently in the backing JobDB into the cache.
+func (c *LockFreeJobCache) Start() error {
+	jobs, err := c.jobDB.GetAll()
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, j := range jobs {
+		c.jobs[j.Id] = j
+	}
+	return nil
+}
+
+func (c *LockFreeJobCache) Get(id string) (*Job, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	j, ok := c.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound(id)
+	}
+	return j, nil
+}
+
+func (c *LockFreeJobCache) GetAll() map[string]*Job {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	all := make(map[string]*Job, len(c.jobs))
+	for id, j := range c.jobs {
+		all[id] = j
+	}
+	return all
+}
+
+func (c *LockFreeJobCache) Set(j *Job) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.jobs[j.Id] = j
+}
+
+func (c *LockFreeJobCache) Delete(id string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.jobs[id]; !ok {
+		return ErrJobNotFound(id)
+	}
+	delete(c.jobs, id)
+	return c.jobDB.Delete(id)
+}
@@ -0,0 +1,145 @@
+package job
+
+import "strings"
+
+// latestRun returns the most recently-ran entry of runs, or nil if runs is
+// empty.
+func latestRun(runs []*JobStat) *JobStat {
+	var latest *JobStat
+	for _, run := range runs {
+		if latest == nil || run.RanAt.After(latest.RanAt) {
+			latest = run
+		}
+	}
+	return latest
+}
+
+// dependenciesSatisfied reports whether every parent listed in j.Dependencies
+// has run recently enough, and in a way, that satisfies j.DependencyMode.
+// The returned key identifies the specific set of parent runs that
+// satisfied it, so callers can avoid re-triggering j for the same parent
+// state on a later tick.
+func (s *Scheduler) dependenciesSatisfied(j *Job) (satisfied bool, key string) {
+	if len(j.Dependencies) == 0 {
+		return false, ""
+	}
+
+	runIds := make([]string, 0, len(j.Dependencies))
+	anySuccess := false
+	for _, parentID := range j.Dependencies {
+		runs, err := s.jobDB.GetAllRuns(parentID)
+		if err != nil {
+			return false, ""
+		}
+		latest := latestRun(runs)
+		if latest == nil {
+			// A parent that hasn't run yet can't block AnySuccess if
+			// another parent has already succeeded; for AllSuccess and
+			// AllComplete every parent must have run, so it's a block.
+			if j.DependencyMode == AnySuccess {
+				continue
+			}
+			return false, ""
+		}
+
+		switch j.DependencyMode {
+		case AllComplete:
+			if latest.State != StateSuccess && latest.State != StateDead {
+				return false, ""
+			}
+		case AnySuccess:
+			if latest.State == StateSuccess {
+				anySuccess = true
+			}
+		default: // AllSuccess
+			if latest.State != StateSuccess {
+				return false, ""
+			}
+		}
+
+		runIds = append(runIds, latest.Id)
+	}
+
+	if j.DependencyMode == AnySuccess && !anySuccess {
+		return false, ""
+	}
+
+	return true, strings.Join(runIds, ",")
+}
+
+// GraphNode describes one job in a resolved dependency graph: its identity
+// plus the state of its most recent run, if any.
+type GraphNode struct {
+	JobId        string   `json:"job_id"`
+	Name         string   `json:"name"`
+	LastRunState RunState `json:"last_run_state,omitempty"`
+}
+
+// Graph is the dependency graph immediately surrounding one job: its
+// direct parents and children, each annotated with their most recent run
+// status.
+type Graph struct {
+	JobId    string      `json:"job_id"`
+	Parents  []GraphNode `json:"parents"`
+	Children []GraphNode `json:"children"`
+}
+
+// ResolveGraph builds the Graph around jobID from jobDB's current jobs and
+// run history.
+func ResolveGraph(jobDB JobDB, jobID string) (*Graph, error) {
+	j, err := jobDB.Get(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := jobDB.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Job, len(all))
+	for _, candidate := range all {
+		byID[candidate.Id] = candidate
+	}
+
+	graph := &Graph{JobId: jobID}
+	for _, parentID := range j.Dependencies {
+		parent, ok := byID[parentID]
+		if !ok {
+			continue
+		}
+		node, err := graphNodeFor(jobDB, parent)
+		if err != nil {
+			return nil, err
+		}
+		graph.Parents = append(graph.Parents, node)
+	}
+
+	for _, candidate := range all {
+		for _, dep := range candidate.Dependencies {
+			if dep != jobID {
+				continue
+			}
+			node, err := graphNodeFor(jobDB, candidate)
+			if err != nil {
+				return nil, err
+			}
+			graph.Children = append(graph.Children, node)
+			break
+		}
+	}
+
+	return graph, nil
+}
+
+func graphNodeFor(jobDB JobDB, j *Job) (GraphNode, error) {
+	node := GraphNode{JobId: j.Id, Name: j.Name}
+
+	runs, err := jobDB.GetAllRuns(j.Id)
+	if err != nil {
+		return GraphNode{}, err
+	}
+	if latest := latestRun(runs); latest != nil {
+		node.LastRunState = latest.State
+	}
+	return node, nil
+}
@@ -0,0 +1,99 @@
+package job
+
+import "testing"
+
+func TestSaveAllowsAcyclicJobs(t *testing.T) {
+	db := NewMemoryDB()
+
+	parent := GetMockJob()
+	if err := db.Save(parent); err != nil {
+		t.Fatalf("expected a job with no dependencies to save, got %v", err)
+	}
+
+	child := GetMockJobWithDependencies(parent)
+	if err := db.Save(child); err != nil {
+		t.Fatalf("expected an acyclic dependent job to save, got %v", err)
+	}
+}
+
+func TestSaveRejectsDependencyCycle(t *testing.T) {
+	db := NewMemoryDB()
+
+	a := GetMockJob()
+	b := GetMockJobWithDependencies(a)
+	if err := db.Save(a); err != nil {
+		t.Fatalf("save a: %v", err)
+	}
+	if err := db.Save(b); err != nil {
+		t.Fatalf("save b: %v", err)
+	}
+
+	a.Dependencies = []string{b.Id}
+	if err := db.Save(a); err == nil {
+		t.Fatal("expected saving a cycle (a -> b -> a) to fail")
+	}
+}
+
+func TestRollbackRejectsDependencyCycle(t *testing.T) {
+	db := NewMemoryDB()
+
+	a := GetMockJob()
+	if err := db.Save(a); err != nil { // a v1: no dependencies
+		t.Fatalf("save a v1: %v", err)
+	}
+
+	b := GetMockJobWithDependencies(a)
+	if err := db.Save(b); err != nil { // b v1: depends on a
+		t.Fatalf("save b v1: %v", err)
+	}
+
+	b.Dependencies = nil
+	if err := db.Save(b); err != nil { // b v2: no dependencies
+		t.Fatalf("save b v2: %v", err)
+	}
+
+	a.Dependencies = []string{b.Id}
+	if err := db.Save(a); err != nil { // a v2: depends on b (fine, b has none right now)
+		t.Fatalf("save a v2: %v", err)
+	}
+
+	a.Dependencies = nil
+	if err := db.Save(a); err != nil { // a v3: no dependencies again
+		t.Fatalf("save a v3: %v", err)
+	}
+
+	b.Dependencies = []string{a.Id}
+	if err := db.Save(b); err != nil { // b v3: depends on a (fine, a has none right now)
+		t.Fatalf("save b v3: %v", err)
+	}
+
+	// Current live state is a (no deps) <- b (depends on a). Rolling a
+	// back to v2 (depends on b) would make the live graph cyclic even
+	// though neither Save call above ever saw that combination directly —
+	// Rollback must run the same check Save does.
+	if err := db.Rollback(a.Id, 2); err == nil {
+		t.Fatal("expected rollback to reject reintroducing a dependency cycle")
+	}
+}
+
+func TestDependenciesSatisfiedAnySuccessIgnoresUnrunParent(t *testing.T) {
+	db := NewMemoryDB()
+	s := NewScheduler(NewLockFreeJobCache(db), db, nil)
+
+	ranParent := GetMockJob()
+	unrunParent := GetMockJob()
+
+	succeeded := NewJobStat(ranParent)
+	succeeded.State = StateSuccess
+	if err := db.SaveRun(succeeded); err != nil {
+		t.Fatalf("save run: %v", err)
+	}
+
+	dependent := GetMockJobWithDependencies(ranParent, unrunParent)
+	dependent.DependencyMode = AnySuccess
+
+	satisfied, _ := s.dependenciesSatisfied(dependent)
+	if !satisfied {
+		t.Fatal("expected AnySuccess to be satisfied by one succeeded parent, even though another never ran")
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import "fmt"
+
+// DependencyMode controls when a job with Dependencies becomes eligible to
+// run, based on the most recent run of each parent in Dependencies.
+type DependencyMode string
+
+const (
+	// AllSuccess requires every parent's most recent run to have succeeded.
+	AllSuccess DependencyMode = "all_success"
+	// AnySuccess requires at least one parent's most recent run to have
+	// succeeded.
+	AnySuccess DependencyMode = "any_success"
+	// AllComplete requires every parent's most recent run to have finished,
+	// success or not.
+	AllComplete DependencyMode = "all_complete"
+)
+
+// ErrDependencyCycle is returned by JobDB.Save when persisting a job would
+// introduce a cycle in the dependency graph.
+type ErrDependencyCycle string
+
+func (id ErrDependencyCycle) Error() string {
+	return fmt.Sprintf("job: saving %q would introduce a dependency cycle", string(id))
+}
@@ -0,0 +1,11 @@
+package job
+
+import "fmt"
+
+// ErrJobNotFound is returned by JobDB implementations when a lookup by id
+// finds nothing.
+type ErrJobNotFound string
+
+func (id ErrJobNotFound) Error() string {
+	return fmt.Sprintf("job: no job found with id %q", string(id))
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobVersion is an immutable snapshot of a Job as it existed after one of
+// its mutations, keyed by the compound (JobId, Version) index. JobDB
+// implementations append one of these every time Save persists a change,
+// so operators can see what changed and when, and restore any prior
+// configuration via Rollback.
+type JobVersion struct {
+	JobId     string    `json:"job_id"`
+	Version   uint64    `json:"version"`
+	Payload   []byte    `json:"payload"`
+	MutatedBy string    `json:"mutated_by"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Job deserializes the full job payload captured at this version.
+func (v *JobVersion) Job() (*Job, error) {
+	j := &Job{}
+	if err := json.Unmarshal(v.Payload, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// newJobVersion snapshots job as version, attributing the change to
+// mutator. Until nextkala threads a real caller identity through Save, the
+// job's Owner is used as the mutator.
+func newJobVersion(job *Job, version uint64, mutator string) (*JobVersion, error) {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	return &JobVersion{
+		JobId:     job.Id,
+		Version:   version,
+		Payload:   payload,
+		MutatedBy: mutator,
+		Timestamp: time.Now(),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package job
+
+import "testing"
+
+func TestMemoryDBHistoryAndRollback(t *testing.T) {
+	db := NewMemoryDB()
+
+	j := NewJob("test", "true", "owner@example.com")
+	if err := db.Save(j); err != nil { // version 1
+		t.Fatalf("save: %v", err)
+	}
+
+	j.Command = "false"
+	if err := db.Save(j); err != nil { // version 2
+		t.Fatalf("save: %v", err)
+	}
+
+	history, err := db.GetHistory(j.Id)
+	if err != nil {
+		t.Fatalf("get history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(history))
+	}
+
+	if err := db.Rollback(j.Id, 1); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	restored, err := db.Get(j.Id)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if restored.Command != "true" {
+		t.Fatalf("expected rollback to restore Command %q, got %q", "true", restored.Command)
+	}
+
+	history, err = db.GetHistory(j.Id)
+	if err != nil {
+		t.Fatalf("get history after rollback: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected rollback to append a 3rd version, got %d", len(history))
+	}
+}
+
+func TestArchiveAndRunJobRecordVersions(t *testing.T) {
+	db := NewMemoryDB()
+
+	j := NewJob("test", "true", "owner@example.com")
+	if err := db.Save(j); err != nil { // version 1
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := db.ArchiveJob(j.Id); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	archived, err := db.Get(j.Id)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !archived.Disabled {
+		t.Fatal("expected ArchiveJob to disable the job")
+	}
+
+	if err := db.RunJob(j.Id); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	history, err := db.GetHistory(j.Id)
+	if err != nil {
+		t.Fatalf("get history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected ArchiveJob and RunJob to each append a version (3 total), got %d", len(history))
+	}
+}
@@ -0,0 +1,162 @@
+// Package job implements nextkala's job model: the Job definition itself,
+// its persistence interface (JobDB), and the in-memory scheduling cache
+// (JobCache) that decides when each job is next due to run.
+package job
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nextiva/nextkala/utils/iso8601"
+)
+
+// JobType distinguishes how a Job is executed.
+type JobType int
+
+const (
+	// LocalJob runs Command in a subprocess on the node that owns it.
+	LocalJob JobType = iota
+	// RemoteJob invokes RemoteProperties.Url instead of running a local command.
+	RemoteJob
+)
+
+// Job is the user-facing definition of a scheduled unit of work.
+type Job struct {
+	Name    string `json:"name"`
+	Id      string `json:"id"`
+	Command string `json:"command"`
+	Owner   string `json:"owner"`
+
+	JobType          JobType          `json:"job_type"`
+	RemoteProperties RemoteProperties `json:"remote_properties"`
+
+	// Schedule is an ISO 8601 repeating interval, e.g. "R2/2020-01-01T00:00:00Z/P1D".
+	Schedule string `json:"schedule"`
+
+	Retries  uint `json:"retries"`
+	Disabled bool `json:"disabled"`
+
+	// Dependencies lists the ids of parent jobs that must run before this
+	// job is eligible to fire; DependencyMode controls how their most
+	// recent runs are combined to decide that.
+	Dependencies   []string       `json:"dependencies,omitempty"`
+	DependencyMode DependencyMode `json:"dependency_mode,omitempty"`
+
+	// timesToRepeat is parsed out of Schedule; -1 means repeat forever.
+	timesToRepeat int
+	delayDuration *iso8601.Duration
+	scheduleTime  time.Time
+
+	// lastTriggerKey is the dependency-satisfaction key (see
+	// Scheduler.dependenciesSatisfied) that last caused this job to run,
+	// so a steady parent state doesn't retrigger it every tick.
+	lastTriggerKey string
+
+	// ranChan is closed each time a run of this job completes; it exists
+	// purely to let tests synchronize on job execution.
+	ranChan chan struct{}
+
+	lock sync.RWMutex
+}
+
+// NewJob constructs a Job with a fresh id, ready to be saved.
+func NewJob(name, command, owner string) *Job {
+	return &Job{
+		Id:      uuid.New().String(),
+		Name:    name,
+		Command: command,
+		Owner:   owner,
+		ranChan: make(chan struct{}),
+	}
+}
+
+// Init parses the job's Schedule and registers it with cache so it is
+// eligible to run. It must be called once before a Job is handed to a
+// scheduler.
+func (j *Job) Init(cache JobCache) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.ranChan == nil {
+		j.ranChan = make(chan struct{})
+	}
+
+	if j.Schedule != "" {
+		repeat, scheduleTime, delay, err := parseSchedule(j.Schedule)
+		if err != nil {
+			return err
+		}
+		j.timesToRepeat = repeat
+		j.scheduleTime = scheduleTime
+		j.delayDuration = delay
+	}
+
+	cache.Set(j)
+	return nil
+}
+
+// isDue reports whether j's next scheduled run is at or before now. A
+// disabled job, or one with no schedule, is never due.
+func (j *Job) isDue(now time.Time) bool {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
+	if j.Disabled || j.Schedule == "" {
+		return false
+	}
+	if j.timesToRepeat == 0 {
+		return false
+	}
+	return !j.scheduleTime.After(now)
+}
+
+// markRan advances past the current scheduleTime, decrements the repeat
+// count, and signals any waiters on ranChan.
+func (j *Job) markRan() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.delayDuration != nil {
+		j.scheduleTime = j.delayDuration.RelativeTo(j.scheduleTime)
+	}
+	if j.timesToRepeat > 0 {
+		j.timesToRepeat--
+	}
+
+	if j.ranChan != nil {
+		close(j.ranChan)
+	}
+	j.ranChan = make(chan struct{})
+}
+
+// ForceRun marks j as due immediately, ignoring its normal schedule. It
+// backs JobDB.RunJob.
+func (j *Job) ForceRun() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.scheduleTime = time.Time{}
+}
+
+// Archive disables j so the scheduler stops firing it, without deleting
+// its history. It backs JobDB.ArchiveJob.
+func (j *Job) Archive() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.Disabled = true
+}
+
+// claimTrigger reports whether key (the dependency-satisfaction state
+// that made j due) is new since the last time j ran via its dependencies.
+// It records key as claimed so the same parent state won't retrigger j on
+// a later tick.
+func (j *Job) claimTrigger(key string) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if key == j.lastTriggerKey {
+		return false
+	}
+	j.lastTriggerKey = key
+	return true
+}
@@ -0,0 +1,49 @@
+package job
+
+// JobDB is the persistence interface implemented by each supported backend
+// (BoltDB, Redis, Postgres, ...). It is responsible for durably storing
+// jobs themselves as well as the JobStat history of their runs.
+type JobDB interface {
+	GetAll() ([]*Job, error)
+	Get(id string) (*Job, error)
+	Delete(id string) error
+	Save(job *Job) error
+	Close() error
+
+	SaveRun(stat *JobStat) error
+	UpdateRun(stat *JobStat) error
+	GetAllRuns(jobID string) ([]*JobStat, error)
+	GetRun(runID string) (*JobStat, error)
+	DeleteRun(jobID string) error
+	ClearExpiredRuns() error
+
+	// GetHistory returns every recorded version of jobID, oldest first.
+	GetHistory(jobID string) ([]*JobVersion, error)
+	// GetVersion returns a single historical version of jobID.
+	GetVersion(jobID string, version uint64) (*JobVersion, error)
+	// Rollback restores jobID to the payload captured at version, recording
+	// the restore itself as a new version.
+	Rollback(jobID string, version uint64) error
+
+	// Transition moves the run runID into newState. Transition, SaveRun and
+	// UpdateRun all file the run under its current State, so the per-state
+	// inspector buckets stay consistent with the individual JobStat records.
+	Transition(runID string, newState RunState) error
+
+	// ListScheduled, ListRetry, ListDead, and ListActive page through runs
+	// currently in the matching state, optionally filtered to jobs named
+	// qname (all jobs if qname is empty).
+	ListScheduled(qname string, pageSize, page int) ([]*JobStat, error)
+	ListRetry(qname string, pageSize, page int) ([]*JobStat, error)
+	ListDead(qname string, pageSize, page int) ([]*JobStat, error)
+	ListActive(qname string, pageSize, page int) ([]*JobStat, error)
+
+	// ArchiveJob disables a job so the scheduler stops firing it, without
+	// deleting its history.
+	ArchiveJob(id string) error
+	// RunJob forces job id to be treated as immediately due on the next
+	// scheduler tick.
+	RunJob(id string) error
+	// DeleteAllRetryJobs discards every run currently in the retry state.
+	DeleteAllRetryJobs() error
+}
@@ -0,0 +1,50 @@
+package job
+
+import "testing"
+
+func TestTransitionMovesRunBetweenStateBuckets(t *testing.T) {
+	db := NewMemoryDB()
+
+	j := GetMockJob()
+	stat := NewJobStat(j)
+	stat.State = StatePending
+	if err := db.SaveRun(stat); err != nil {
+		t.Fatalf("save run: %v", err)
+	}
+
+	if err := db.Transition(stat.Id, StateActive); err != nil {
+		t.Fatalf("transition to active: %v", err)
+	}
+	active, err := db.ListActive("", 10, 0)
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if len(active) != 1 || active[0].Id != stat.Id {
+		t.Fatalf("expected the run to appear in the active bucket after Transition, got %v", active)
+	}
+
+	if err := db.Transition(stat.Id, StateDead); err != nil {
+		t.Fatalf("transition to dead: %v", err)
+	}
+	active, err = db.ListActive("", 10, 0)
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if len(active) != 0 {
+		t.Fatalf("expected the run to have left the active bucket, got %v", active)
+	}
+	dead, err := db.ListDead("", 10, 0)
+	if err != nil {
+		t.Fatalf("list dead: %v", err)
+	}
+	if len(dead) != 1 || dead[0].Id != stat.Id {
+		t.Fatalf("expected the run to appear in the dead bucket after Transition, got %v", dead)
+	}
+}
+
+func TestTransitionUnknownRunIsAnError(t *testing.T) {
+	db := NewMemoryDB()
+	if err := db.Transition("does-not-exist", StateActive); err == nil {
+		t.Fatal("expected transitioning an unknown run id to fail")
+	}
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nextiva/nextkala/utils/iso8601"
+)
+
+// parseSchedule parses an ISO 8601 repeating interval of the form
+// "R<n>/<start-time>/<duration>", e.g. "R2/2020-01-01T00:00:00Z/P1D".
+// An empty repeat count ("R/...") means repeat indefinitely, represented
+// as -1.
+func parseSchedule(schedule string) (repeat int, startTime time.Time, delay *iso8601.Duration, err error) {
+	parts := strings.Split(schedule, "/")
+	if len(parts) != 3 {
+		return 0, time.Time{}, nil, fmt.Errorf("job: invalid schedule %q: expected R<n>/<time>/<duration>", schedule)
+	}
+	if !strings.HasPrefix(parts[0], "R") {
+		return 0, time.Time{}, nil, fmt.Errorf("job: invalid schedule %q: missing leading R", schedule)
+	}
+
+	repeatStr := strings.TrimPrefix(parts[0], "R")
+	if repeatStr == "" {
+		repeat = -1
+	} else {
+		repeat, err = strconv.Atoi(repeatStr)
+		if err != nil {
+			return 0, time.Time{}, nil, fmt.Errorf("job: invalid repeat count in schedule %q: %w", schedule, err)
+		}
+	}
+
+	startTime, err = time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return 0, time.Time{}, nil, fmt.Errorf("job: invalid start time in schedule %q: %w", schedule, err)
+	}
+
+	delay, err = iso8601.FromString(parts[2])
+	if err != nil {
+		return 0, time.Time{}, nil, fmt.Errorf("job: invalid delay in schedule %q: %w", schedule, err)
+	}
+
+	return repeat, startTime, delay, nil
+}
@@ -0,0 +1,106 @@
+package job
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PushgatewayConfig configures the optional Prometheus Pushgateway sink. If
+// URL is empty, pushing is disabled. Short-lived one-off jobs can finish
+// between Prometheus scrapes, so pushing per-run metrics here — rather than
+// only exposing them for a pull-based scrape — keeps success/failure
+// alerting reliable for them.
+type PushgatewayConfig struct {
+	URL string `json:"url"`
+
+	BasicAuthUsername string `json:"basic_auth_username"`
+	BasicAuthPassword string `json:"basic_auth_password"`
+
+	TLSConfig *tls.Config `json:"-"`
+
+	// GroupingLabels are added to every push alongside job and instance,
+	// e.g. {"env": "prod"}.
+	GroupingLabels map[string]string `json:"grouping_labels"`
+}
+
+// StatPusher pushes the metrics for one finished run to a Pushgateway. It's
+// an interface so tests can substitute a fake instead of standing up a
+// real Pushgateway.
+type StatPusher interface {
+	Push(jobName, instance string, stat *JobStat) error
+}
+
+// NewStatPusher builds a StatPusher from cfg, or a no-op pusher if cfg.URL
+// is empty.
+func NewStatPusher(cfg PushgatewayConfig) StatPusher {
+	if cfg.URL == "" {
+		return noopPusher{}
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	if cfg.TLSConfig != nil {
+		client.Transport = &http.Transport{TLSClientConfig: cfg.TLSConfig}
+	}
+	return &pushgatewayPusher{cfg: cfg, client: client}
+}
+
+type noopPusher struct{}
+
+func (noopPusher) Push(string, string, *JobStat) error { return nil }
+
+type pushgatewayPusher struct {
+	cfg    PushgatewayConfig
+	client *http.Client
+}
+
+func (p *pushgatewayPusher) Push(jobName, instance string, stat *JobStat) error {
+	pushURL := fmt.Sprintf("%s/metrics/job/%s/instance/%s", p.cfg.URL, url.PathEscape(jobName), url.PathEscape(instance))
+	for k, v := range p.cfg.GroupingLabels {
+		pushURL += fmt.Sprintf("/%s/%s", url.PathEscape(k), url.PathEscape(v))
+	}
+
+	req, err := http.NewRequest(http.MethodPut, pushURL, strings.NewReader(p.render(jobName, stat)))
+	if err != nil {
+		return err
+	}
+	if p.cfg.BasicAuthUsername != "" {
+		req.SetBasicAuth(p.cfg.BasicAuthUsername, p.cfg.BasicAuthPassword)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("job: pushgateway returned status %s", resp.Status)
+	}
+	return nil
+}
+
+func (p *pushgatewayPusher) render(jobName string, stat *JobStat) string {
+	exitCode := 0
+	if !stat.Success {
+		exitCode = 1
+	}
+
+	out := fmt.Sprintf(
+		"nextkala_job_last_run_timestamp{job=%q} %d\n"+
+			"nextkala_job_last_duration_seconds{job=%q} %f\n"+
+			"nextkala_job_last_exit_code{job=%q} %d\n"+
+			"nextkala_job_retries_used{job=%q} %d\n",
+		jobName, stat.RanAt.Unix(),
+		jobName, stat.ExecutionDuration.Seconds(),
+		jobName, exitCode,
+		jobName, stat.NumberOfRetries,
+	)
+	if stat.Success {
+		out += fmt.Sprintf("nextkala_job_last_success_timestamp{job=%q} %d\n", jobName, stat.RanAt.Unix())
+	}
+	return out
+}
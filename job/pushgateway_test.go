@@ -0,0 +1,66 @@
+package job
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPushgatewayEscapesURLPathSegments(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// r.URL.Path is already decoded by net/http, so it can never
+		// contain an escaped substring; assert against the escaped form.
+		gotPath = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pusher := NewStatPusher(PushgatewayConfig{
+		URL:            srv.URL,
+		GroupingLabels: map[string]string{"env": "prod/staging"},
+	})
+
+	stat := &JobStat{RanAt: time.Now(), Success: true}
+	if err := pusher.Push("jobs/with a slash", "instance a", stat); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	if strings.Contains(gotPath, "jobs/with a slash") {
+		t.Fatalf("expected job name to be path-escaped, got raw value in path %q", gotPath)
+	}
+	if !strings.Contains(gotPath, "jobs%2Fwith") {
+		t.Fatalf("expected escaped job name in path, got %q", gotPath)
+	}
+}
+
+func TestPushgatewayOnlyRendersSuccessTimestampOnSuccess(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pusher := NewStatPusher(PushgatewayConfig{URL: srv.URL})
+
+	failed := &JobStat{RanAt: time.Now(), Success: false}
+	if err := pusher.Push("myjob", "inst", failed); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if strings.Contains(gotBody, "nextkala_job_last_success_timestamp") {
+		t.Fatalf("expected a failed run not to report a success timestamp, got body %q", gotBody)
+	}
+
+	succeeded := &JobStat{RanAt: time.Now(), Success: true}
+	if err := pusher.Push("myjob", "inst", succeeded); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if !strings.Contains(gotBody, "nextkala_job_last_success_timestamp") {
+		t.Fatalf("expected a successful run to report a success timestamp, got body %q", gotBody)
+	}
+}
@@ -0,0 +1,16 @@
+package job
+
+import "net/http"
+
+// RemoteProperties holds the configuration needed to execute a RemoteJob by
+// calling out to an HTTP endpoint rather than running a local command.
+type RemoteProperties struct {
+	Url     string      `json:"url"`
+	Method  string      `json:"method"`
+	Body    string      `json:"body"`
+	Headers http.Header `json:"headers"`
+
+	Timeout int `json:"timeout"`
+
+	ExpectedResponseCodes []int `json:"expected_response_codes"`
+}
@@ -0,0 +1,222 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nextiva/nextkala/cluster"
+)
+
+// Scheduler dispatches due jobs from a JobCache to the Worker registered
+// for their JobType. In an HA deployment, every node constructs a
+// Scheduler, but only the one holding leader is allowed to actually tick —
+// this is what lets nextkala run as multiple replicas without duplicate
+// firings. Dispatch is in-process: each due job runs on the Worker
+// registered with the leader's own Scheduler. Workers on other nodes, or
+// in a process with no Scheduler, are not handed any of its jobs.
+type Scheduler struct {
+	cache      JobCache
+	jobDB      JobDB
+	leader     cluster.SchedulerLeader
+	workers    map[JobType]Worker
+	interval   time.Duration
+	pusher     StatPusher
+	instanceID string
+
+	// inFlight tracks the ids of jobs with a dispatch currently running,
+	// so a job whose execution outlasts interval isn't dispatched again
+	// on top of itself before it finishes.
+	inFlightMu sync.Mutex
+	inFlight   map[string]struct{}
+}
+
+func NewScheduler(cache JobCache, jobDB JobDB, leader cluster.SchedulerLeader) *Scheduler {
+	return &Scheduler{
+		cache:    cache,
+		jobDB:    jobDB,
+		leader:   leader,
+		workers:  map[JobType]Worker{},
+		interval: time.Second,
+		pusher:   noopPusher{},
+		inFlight: map[string]struct{}{},
+	}
+}
+
+// RegisterWorker makes w responsible for running jobs of its JobType.
+func (s *Scheduler) RegisterWorker(w Worker) {
+	s.workers[w.JobType()] = w
+}
+
+// SetPushgateway enables pushing per-run metrics to a Pushgateway,
+// identifying this node as instanceID in the grouping key.
+func (s *Scheduler) SetPushgateway(cfg PushgatewayConfig, instanceID string) {
+	s.pusher = NewStatPusher(cfg)
+	s.instanceID = instanceID
+}
+
+// Run blocks, re-acquiring leadership and ticking while held, until ctx is
+// canceled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		lost, err := s.leader.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		stop := make(chan struct{})
+		go s.tickLoop(stop)
+
+		select {
+		case <-ctx.Done():
+			close(stop)
+			return ctx.Err()
+		case <-lost:
+			close(stop)
+			log.Printf("job: lost scheduler leadership, stepping down")
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *Scheduler) tickLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.tick(now)
+		}
+	}
+}
+
+func (s *Scheduler) tick(now time.Time) {
+	for _, j := range s.cache.GetAll() {
+		triggeredBy, due := s.dueReason(j, now)
+		if !due {
+			continue
+		}
+
+		w, ok := s.workers[j.JobType]
+		if !ok {
+			log.Printf("job: no worker registered for job type %v (job %s)", j.JobType, j.Id)
+			continue
+		}
+
+		if !s.tryStartRun(j.Id) {
+			continue
+		}
+
+		go s.dispatch(w, j, triggeredBy)
+	}
+}
+
+// tryStartRun reports whether jobID has no run currently in flight, and if
+// so marks it as started. Callers must call finishRun once that run
+// completes.
+func (s *Scheduler) tryStartRun(jobID string) bool {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+
+	if _, running := s.inFlight[jobID]; running {
+		return false
+	}
+	s.inFlight[jobID] = struct{}{}
+	return true
+}
+
+func (s *Scheduler) finishRun(jobID string) {
+	s.inFlightMu.Lock()
+	delete(s.inFlight, jobID)
+	s.inFlightMu.Unlock()
+}
+
+// dueReason reports whether j should run now, and if so why: either its
+// own Schedule is due, or — for a job with Dependencies — its parents'
+// most recent runs newly satisfy its DependencyMode. A disabled job is
+// never due either way.
+func (s *Scheduler) dueReason(j *Job, now time.Time) (triggeredBy string, due bool) {
+	if j.isDue(now) {
+		return "schedule", true
+	}
+
+	j.lock.RLock()
+	disabled := j.Disabled
+	j.lock.RUnlock()
+	if disabled {
+		return "", false
+	}
+
+	satisfied, key := s.dependenciesSatisfied(j)
+	if !satisfied || !j.claimTrigger(key) {
+		return "", false
+	}
+	return "dependency:" + key, true
+}
+
+func (s *Scheduler) dispatch(w Worker, j *Job, triggeredBy string) {
+	defer s.finishRun(j.Id)
+
+	stat := NewJobStat(j)
+	stat.TriggeredBy = triggeredBy
+	if err := s.jobDB.SaveRun(stat); err != nil {
+		log.Printf("job: failed to save pending run %s for job %s: %v", stat.Id, j.Id, err)
+	}
+
+	stat.State = StateActive
+	if err := s.jobDB.Transition(stat.Id, stat.State); err != nil {
+		log.Printf("job: failed to mark run %s active: %v", stat.Id, err)
+	}
+
+	start := time.Now()
+	var attempt error
+	for attempts := uint(0); ; attempts++ {
+		attempt = w.Work(context.Background(), j)
+		if attempt == nil {
+			break
+		}
+		if attempts >= j.Retries {
+			break
+		}
+		stat.NumberOfRetries = attempts + 1
+		stat.State = StateRetry
+		s.updateRun(stat)
+		log.Printf("job: attempt %d of job %s failed, retrying: %v", attempts+1, j.Id, attempt)
+	}
+	stat.ExecutionDuration = time.Since(start)
+	stat.Success = attempt == nil
+	if stat.Success {
+		stat.State = StateSuccess
+	} else {
+		stat.State = StateDead
+		log.Printf("job: run of job %s failed after %d attempts: %v", j.Id, stat.NumberOfRetries+1, attempt)
+	}
+
+	j.markRan()
+	s.finalizeRun(j, stat)
+}
+
+// updateRun persists stat in full, for transitions that change more than
+// just State (e.g. bumping NumberOfRetries on a retry, or recording
+// ExecutionDuration/Success at the end of a run). A transition that only
+// changes State goes through jobDB.Transition instead.
+func (s *Scheduler) updateRun(stat *JobStat) {
+	if err := s.jobDB.UpdateRun(stat); err != nil {
+		log.Printf("job: failed to update run %s to state %s: %v", stat.Id, stat.State, err)
+	}
+}
+
+// finalizeRun persists stat's terminal state and, if a Pushgateway is
+// configured, pushes its metrics so that short-lived jobs still register
+// reliably even if they finish between Prometheus scrapes.
+func (s *Scheduler) finalizeRun(j *Job, stat *JobStat) {
+	s.updateRun(stat)
+
+	if err := s.pusher.Push(j.Name, s.instanceID, stat); err != nil {
+		log.Printf("job: failed to push metrics for run %s: %v", stat.Id, err)
+	}
+}
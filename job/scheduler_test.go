@@ -0,0 +1,202 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingWorker fails its first failUntil attempts, then succeeds.
+// seenInFlight records, on each attempt, whether the run was already
+// visible in the active or retry bucket at that point — proving dispatch
+// persists each transition before calling Work rather than only at the
+// end. The first attempt should find it active; later attempts (after a
+// failed attempt moves it to retry) should find it there instead.
+type countingWorker struct {
+	jobType      JobType
+	failUntil    int
+	calls        int
+	db           JobDB
+	seenInFlight []bool
+}
+
+func (w *countingWorker) JobType() JobType { return w.jobType }
+
+func (w *countingWorker) Work(ctx context.Context, j *Job) error {
+	w.calls++
+	if w.db != nil {
+		w.seenInFlight = append(w.seenInFlight, runIsInFlight(w.db, j.Id))
+	}
+	if w.calls <= w.failUntil {
+		return errors.New("not yet")
+	}
+	return nil
+}
+
+// runIsInFlight reports whether jobID has a run currently in the active or
+// retry bucket.
+func runIsInFlight(db JobDB, jobID string) bool {
+	for _, list := range []func(string, int, int) ([]*JobStat, error){db.ListActive, db.ListRetry} {
+		runs, _ := list("", 10, 0)
+		for _, run := range runs {
+			if run.JobId == jobID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestDispatchRecordsIntermediateStates(t *testing.T) {
+	db := NewMemoryDB()
+	s := NewScheduler(NewLockFreeJobCache(db), db, nil)
+
+	j := GetMockJob()
+	j.Retries = 2
+	w := &countingWorker{jobType: j.JobType, failUntil: 2, db: db}
+
+	s.dispatch(w, j, "schedule")
+
+	for i, seen := range w.seenInFlight {
+		if !seen {
+			t.Fatalf("expected run to already be in the active or retry bucket during attempt %d", i+1)
+		}
+	}
+
+	dead, err := db.ListDead("", 10, 0)
+	if err != nil {
+		t.Fatalf("list dead: %v", err)
+	}
+	if len(dead) != 0 {
+		t.Fatalf("expected no dead runs, got %d", len(dead))
+	}
+
+	active, err := db.ListActive("", 10, 0)
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if len(active) != 0 {
+		t.Fatalf("expected the run to have left the active bucket once it finished, got %d", len(active))
+	}
+
+	runs, err := db.GetAllRuns(j.Id)
+	if err != nil {
+		t.Fatalf("get all runs: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected a single run record across the whole retry sequence, got %d", len(runs))
+	}
+	if runs[0].State != StateSuccess {
+		t.Fatalf("expected the run to finish successful, got state %s", runs[0].State)
+	}
+	if runs[0].NumberOfRetries != 2 {
+		t.Fatalf("expected 2 recorded retries, got %d", runs[0].NumberOfRetries)
+	}
+}
+
+func TestDispatchMovesThroughRetryBucketBeforeFailingDead(t *testing.T) {
+	db := NewMemoryDB()
+	s := NewScheduler(NewLockFreeJobCache(db), db, nil)
+
+	j := GetMockFailingJob()
+	j.Retries = 1
+	w := &countingWorker{jobType: j.JobType, failUntil: 99}
+
+	s.dispatch(w, j, "schedule")
+
+	dead, err := db.ListDead("", 10, 0)
+	if err != nil {
+		t.Fatalf("list dead: %v", err)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("expected the exhausted run to land in the dead bucket, got %d", len(dead))
+	}
+
+	retry, err := db.ListRetry("", 10, 0)
+	if err != nil {
+		t.Fatalf("list retry: %v", err)
+	}
+	if len(retry) != 0 {
+		t.Fatalf("expected the run to have left the retry bucket once it died, got %d", len(retry))
+	}
+}
+
+// blockingWorker closes started on its first call and then waits on release
+// before returning, so a test can hold a dispatch "in flight" on demand.
+type blockingWorker struct {
+	jobType JobType
+	started chan struct{}
+	release chan struct{}
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (w *blockingWorker) JobType() JobType { return w.jobType }
+
+func (w *blockingWorker) Work(ctx context.Context, j *Job) error {
+	w.mu.Lock()
+	w.calls++
+	first := w.calls == 1
+	w.mu.Unlock()
+
+	if first {
+		close(w.started)
+	}
+	<-w.release
+	return nil
+}
+
+func (w *blockingWorker) callCount() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.calls
+}
+
+func TestTickSkipsJobAlreadyInFlight(t *testing.T) {
+	db := NewMemoryDB()
+	cache := NewLockFreeJobCache(db)
+	s := NewScheduler(cache, db, nil)
+
+	j := GetMockRecurringJobWithSchedule(time.Now().Add(-time.Hour), "P1D")
+	cache.Set(j)
+
+	w := &blockingWorker{jobType: j.JobType, started: make(chan struct{}), release: make(chan struct{})}
+	s.RegisterWorker(w)
+
+	now := time.Now()
+	s.tick(now) // dispatches the one in-flight run
+
+	select {
+	case <-w.started:
+	case <-time.After(time.Second):
+		t.Fatal("worker never started")
+	}
+
+	// The job is still "due" by schedule (markRan hasn't run yet), so
+	// without the in-flight guard these would dispatch it again.
+	s.tick(now)
+	s.tick(now)
+
+	close(w.release)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.inFlightMu.Lock()
+		_, running := s.inFlight[j.Id]
+		s.inFlightMu.Unlock()
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("dispatch never finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if calls := w.callCount(); calls != 1 {
+		t.Fatalf("expected only 1 dispatch while a run was in flight, got %d", calls)
+	}
+}
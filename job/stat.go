@@ -0,0 +1,40 @@
+package job
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStat is a record of a single execution (a "run") of a Job.
+type JobStat struct {
+	Id      string `json:"id"`
+	JobId   string `json:"job_id"`
+	JobName string `json:"job_name"`
+
+	State RunState `json:"state"`
+
+	// TriggeredBy records why this run fired: "schedule" (which includes a
+	// run forced via JobDB.RunJob), or "dependency:<parent run ids>" for a
+	// run of a DAG-dependent job.
+	TriggeredBy string `json:"triggered_by"`
+
+	RanAt             time.Time     `json:"ran_at"`
+	ExecutionDuration time.Duration `json:"execution_duration"`
+
+	Success         bool `json:"success"`
+	NumberOfRetries uint `json:"number_of_retries"`
+}
+
+// NewJobStat creates a pending, schedule-triggered JobStat for j with a
+// fresh run id and RanAt set to now.
+func NewJobStat(j *Job) *JobStat {
+	return &JobStat{
+		Id:          uuid.New().String(),
+		JobId:       j.Id,
+		JobName:     j.Name,
+		State:       StatePending,
+		TriggeredBy: "schedule",
+		RanAt:       time.Now(),
+	}
+}
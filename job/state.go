@@ -0,0 +1,14 @@
+package job
+
+// RunState is the lifecycle stage of a single JobStat run, modeled after
+// task-queue introspection tools: a run is observed moving
+// pending -> active -> retry -> dead|success.
+type RunState string
+
+const (
+	StatePending RunState = "pending"
+	StateActive  RunState = "active"
+	StateRetry   RunState = "retry"
+	StateDead    RunState = "dead"
+	StateSuccess RunState = "success"
+)
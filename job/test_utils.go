@@ -3,10 +3,12 @@ package job
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nextiva/nextkala/utils/iso8601"
 )
 
@@ -71,6 +73,64 @@ func (m *MockDB) ClearExpiredRuns() error {
 	return nil
 }
 
+func (m *MockDB) GetHistory(jobID string) ([]*JobVersion, error) {
+	return nil, nil
+}
+
+func (m *MockDB) GetVersion(jobID string, version uint64) (*JobVersion, error) {
+	return nil, nil
+}
+
+func (m *MockDB) Rollback(jobID string, version uint64) error {
+	return nil
+}
+
+// AcquireLease and ReleaseLease make MockDB usable as a cluster.LeaseStore
+// that always wins, so tests can run a cluster.DBLeader without a real
+// scheduler_leader backend.
+func (m *MockDB) AcquireLease(nodeID string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (m *MockDB) ReleaseLease(nodeID string) error {
+	return nil
+}
+
+func (m *MockDB) Transition(runID string, newState RunState) error {
+	if run, ok := m.Runs[runID]; ok {
+		run.State = newState
+	}
+	return nil
+}
+
+func (m *MockDB) ListScheduled(qname string, pageSize, page int) ([]*JobStat, error) {
+	return nil, nil
+}
+
+func (m *MockDB) ListRetry(qname string, pageSize, page int) ([]*JobStat, error) {
+	return nil, nil
+}
+
+func (m *MockDB) ListDead(qname string, pageSize, page int) ([]*JobStat, error) {
+	return nil, nil
+}
+
+func (m *MockDB) ListActive(qname string, pageSize, page int) ([]*JobStat, error) {
+	return nil, nil
+}
+
+func (m *MockDB) ArchiveJob(id string) error {
+	return nil
+}
+
+func (m *MockDB) RunJob(id string) error {
+	return nil
+}
+
+func (m *MockDB) DeleteAllRetryJobs() error {
+	return nil
+}
+
 func NewMockCache() *LockFreeJobCache {
 	db := &MockDB{Runs: make(map[string]*JobStat)}
 	return NewLockFreeJobCache(db)
@@ -78,6 +138,7 @@ func NewMockCache() *LockFreeJobCache {
 
 func GetMockJob() *Job {
 	return &Job{
+		Id:      uuid.New().String(),
 		Name:    "mock_job",
 		Command: "bash -c 'date'",
 		Owner:   "example@example.com",
@@ -87,6 +148,7 @@ func GetMockJob() *Job {
 
 func GetMockFailingJob() *Job {
 	return &Job{
+		Id:      uuid.New().String(),
 		Name:    "mock_failing_job",
 		Command: "asdf",
 		Owner:   "example@example.com",
@@ -96,6 +158,7 @@ func GetMockFailingJob() *Job {
 
 func GetMockRemoteJob(props RemoteProperties) *Job {
 	return &Job{
+		Id:               uuid.New().String(),
 		Name:             "mock_remote_job",
 		Command:          "",
 		JobType:          RemoteJob,
@@ -103,6 +166,17 @@ func GetMockRemoteJob(props RemoteProperties) *Job {
 	}
 }
 
+// GetMockJobWithDependencies returns a job that depends on parents under
+// DependencyMode AllSuccess, for exercising DAG scheduling in tests.
+func GetMockJobWithDependencies(parents ...*Job) *Job {
+	genericMockJob := GetMockJob()
+	for _, parent := range parents {
+		genericMockJob.Dependencies = append(genericMockJob.Dependencies, parent.Id)
+	}
+	genericMockJob.DependencyMode = AllSuccess
+	return genericMockJob
+}
+
 func GetMockJobWithSchedule(repeat int, scheduleTime time.Time, delay string) *Job {
 	genericMockJob := GetMockJob()
 
@@ -170,15 +244,19 @@ func awaitJobRan(t *testing.T, j *Job, timeout time.Duration) {
 var _ JobDB = (*MemoryDB)(nil)
 
 type MemoryDB struct {
-	m    map[string]*Job
-	runs map[string][]*JobStat
-	lock sync.RWMutex
+	m        map[string]*Job
+	runs     map[string][]*JobStat
+	byState  map[RunState]map[string]*JobStat
+	versions map[string][]*JobVersion
+	lock     sync.RWMutex
 }
 
 func NewMemoryDB() *MemoryDB {
 	return &MemoryDB{
-		m:    map[string]*Job{},
-		runs: map[string][]*JobStat{},
+		m:        map[string]*Job{},
+		runs:     map[string][]*JobStat{},
+		byState:  map[RunState]map[string]*JobStat{},
+		versions: map[string][]*JobVersion{},
 	}
 }
 
@@ -215,26 +293,278 @@ func (m *MemoryDB) Delete(id string) error {
 func (m *MemoryDB) Save(j *Job) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+
+	if m.hasCycleLocked(j) {
+		return ErrDependencyCycle(j.Id)
+	}
+	return m.saveLocked(j, j.Owner)
+}
+
+// hasCycleLocked reports whether j, together with the dependency graph of
+// every other job already in m.m, contains a cycle reachable from j.
+// Callers must hold m.lock.
+func (m *MemoryDB) hasCycleLocked(j *Job) bool {
+	deps := func(id string) []string {
+		if id == j.Id {
+			return j.Dependencies
+		}
+		if other, ok := m.m[id]; ok {
+			return other.Dependencies
+		}
+		return nil
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		switch state[id] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[id] = visiting
+		for _, dep := range deps(id) {
+			if visit(dep) {
+				return true
+			}
+		}
+		state[id] = done
+		return false
+	}
+
+	return visit(j.Id)
+}
+
+// saveLocked persists j and appends a new JobVersion attributed to mutator.
+// Callers must hold m.lock.
+func (m *MemoryDB) saveLocked(j *Job, mutator string) error {
 	m.m[j.Id] = j
+
+	version, err := newJobVersion(j, uint64(len(m.versions[j.Id]))+1, mutator)
+	if err != nil {
+		return err
+	}
+	m.versions[j.Id] = append(m.versions[j.Id], version)
+	return nil
+}
+
+func (m *MemoryDB) GetHistory(jobID string) ([]*JobVersion, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	versions := m.versions[jobID]
+	ret := make([]*JobVersion, len(versions))
+	copy(ret, versions)
+	return ret, nil
+}
+
+func (m *MemoryDB) GetVersion(jobID string, version uint64) (*JobVersion, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, v := range m.versions[jobID] {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("job: no version %d for job %q", version, jobID)
+}
+
+func (m *MemoryDB) Rollback(jobID string, version uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var target *JobVersion
+	for _, v := range m.versions[jobID] {
+		if v.Version == version {
+			target = v
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("job: no version %d for job %q", version, jobID)
+	}
+
+	restored, err := target.Job()
+	if err != nil {
+		return err
+	}
+	if m.hasCycleLocked(restored) {
+		return ErrDependencyCycle(jobID)
+	}
+	return m.saveLocked(restored, fmt.Sprintf("rollback:%s", target.MutatedBy))
+}
+
+// AcquireLease and ReleaseLease make MemoryDB usable as a cluster.LeaseStore
+// that always wins, so tests can run a cluster.DBLeader without a real
+// scheduler_leader backend.
+func (m *MemoryDB) AcquireLease(nodeID string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (m *MemoryDB) ReleaseLease(nodeID string) error {
 	return nil
 }
 
 func (m *MemoryDB) SaveRun(run *JobStat) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if run.State == "" {
+		run.State = StatePending
+	}
 	m.runs[run.JobId] = append(m.runs[run.JobId], run)
+	m.putStateLocked(run)
 	return nil
 }
 
 func (m *MemoryDB) UpdateRun(jobStat *JobStat) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	runs := m.runs[jobStat.JobId]
+	found := false
 	for i, run := range runs {
 		if run.Id == jobStat.Id {
 			runs[i] = jobStat
+			found = true
+		}
+	}
+	if !found {
+		m.runs[jobStat.JobId] = append(runs, jobStat)
+	}
+	m.putStateLocked(jobStat)
+	return nil
+}
+
+// putStateLocked removes run from whichever state bucket it previously
+// belonged to and reinserts it under its current State. Callers must hold
+// m.lock.
+func (m *MemoryDB) putStateLocked(run *JobStat) {
+	for _, bucket := range m.byState {
+		delete(bucket, run.Id)
+	}
+	if m.byState[run.State] == nil {
+		m.byState[run.State] = map[string]*JobStat{}
+	}
+	m.byState[run.State][run.Id] = run
+}
+
+// Transition moves runID into newState. SaveRun and UpdateRun both funnel
+// through putStateLocked so every path that changes a run's state keeps
+// the inspector buckets consistent with the individual JobStat record.
+func (m *MemoryDB) Transition(runID string, newState RunState) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, runs := range m.runs {
+		for _, run := range runs {
+			if run.Id == runID {
+				run.State = newState
+				m.putStateLocked(run)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("job: no run found with id %q", runID)
+}
+
+func (m *MemoryDB) listState(state RunState, qname string, pageSize, page int) ([]*JobStat, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matched := make([]*JobStat, 0, len(m.byState[state]))
+	for _, run := range m.byState[state] {
+		if qname != "" && run.JobName != qname {
+			continue
+		}
+		matched = append(matched, run)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].RanAt.Before(matched[j].RanAt) })
+
+	start := page * pageSize
+	if start >= len(matched) {
+		return []*JobStat{}, nil
+	}
+	end := start + pageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], nil
+}
+
+func (m *MemoryDB) ListScheduled(qname string, pageSize, page int) ([]*JobStat, error) {
+	return m.listState(StatePending, qname, pageSize, page)
+}
+
+func (m *MemoryDB) ListActive(qname string, pageSize, page int) ([]*JobStat, error) {
+	return m.listState(StateActive, qname, pageSize, page)
+}
+
+func (m *MemoryDB) ListRetry(qname string, pageSize, page int) ([]*JobStat, error) {
+	return m.listState(StateRetry, qname, pageSize, page)
+}
+
+func (m *MemoryDB) ListDead(qname string, pageSize, page int) ([]*JobStat, error) {
+	return m.listState(StateDead, qname, pageSize, page)
+}
+
+// ArchiveJob disables id and records the change as a new version, same as
+// any other mutation of a Job. Neither Archive nor ForceRun below touch
+// Dependencies, so unlike Save and Rollback neither needs a cycle check.
+func (m *MemoryDB) ArchiveJob(id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	j, exists := m.m[id]
+	if !exists {
+		return ErrJobNotFound(id)
+	}
+	j.Archive()
+	return m.saveLocked(j, "archive")
+}
+
+func (m *MemoryDB) RunJob(id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	j, exists := m.m[id]
+	if !exists {
+		return ErrJobNotFound(id)
+	}
+	j.ForceRun()
+	return m.saveLocked(j, "force-run")
+}
+
+func (m *MemoryDB) DeleteAllRetryJobs() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, run := range m.byState[StateRetry] {
+		runs := m.runs[run.JobId]
+		kept := runs[:0]
+		for _, r := range runs {
+			if r.Id != run.Id {
+				kept = append(kept, r)
+			}
 		}
+		m.runs[run.JobId] = kept
 	}
-	return m.SaveRun(jobStat)
+	m.byState[StateRetry] = map[string]*JobStat{}
+	return nil
 }
 
 func (m *MemoryDB) GetAllRuns(jobID string) (ret []*JobStat, _ error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	for ID, runs := range m.runs {
 		for _, run := range runs {
 			if ID == jobID {
@@ -246,6 +576,9 @@ func (m *MemoryDB) GetAllRuns(jobID string) (ret []*JobStat, _ error) {
 }
 
 func (m *MemoryDB) GetRun(runID string) (ret *JobStat, _ error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
 	for _, runs := range m.runs {
 		for _, run := range runs {
 			if run.Id == runID {
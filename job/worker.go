@@ -0,0 +1,78 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Worker executes one attempt of a job of its JobType and reports whether
+// it succeeded. Workers register with a Scheduler by JobType, and that
+// Scheduler calls them in-process, so only Workers registered on the node
+// currently holding scheduling leadership carry out dispatched work; a
+// process without a leading Scheduler gets none. The Scheduler owns
+// retrying a failed attempt and recording the run's JobStat; Work only
+// needs to run the job once.
+type Worker interface {
+	JobType() JobType
+	Work(ctx context.Context, j *Job) error
+}
+
+// LocalWorker runs LocalJob jobs by executing their Command in a subprocess.
+type LocalWorker struct{}
+
+func (LocalWorker) JobType() JobType { return LocalJob }
+
+func (LocalWorker) Work(ctx context.Context, j *Job) error {
+	return exec.CommandContext(ctx, "/bin/sh", "-c", j.Command).Run()
+}
+
+// RemoteWorker runs RemoteJob jobs by calling their RemoteProperties.Url.
+type RemoteWorker struct{}
+
+func (RemoteWorker) JobType() JobType { return RemoteJob }
+
+func (RemoteWorker) Work(ctx context.Context, j *Job) error {
+	props := j.RemoteProperties
+
+	method := props.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, props.Url, strings.NewReader(props.Body))
+	if err != nil {
+		return err
+	}
+	if props.Headers != nil {
+		req.Header = props.Headers
+	}
+
+	client := &http.Client{}
+	if props.Timeout > 0 {
+		client.Timeout = time.Duration(props.Timeout) * time.Second
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if len(props.ExpectedResponseCodes) == 0 {
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("job: remote job %s got unexpected status %d", j.Id, resp.StatusCode)
+		}
+		return nil
+	}
+
+	for _, code := range props.ExpectedResponseCodes {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+	return fmt.Errorf("job: remote job %s got unexpected status %d", j.Id, resp.StatusCode)
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalWorkerRunsCommand(t *testing.T) {
+	ok := &Job{Command: "true"}
+	if err := (LocalWorker{}).Work(context.Background(), ok); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	fail := &Job{Command: "false"}
+	if err := (LocalWorker{}).Work(context.Background(), fail); err == nil {
+		t.Fatal("expected failing command to return an error")
+	}
+}
+
+func TestRemoteWorkerCallsURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	j := &Job{RemoteProperties: RemoteProperties{Url: srv.URL, Method: http.MethodGet}}
+	if err := (RemoteWorker{}).Work(context.Background(), j); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+}
+
+func TestRemoteWorkerFailsOnUnexpectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	j := &Job{RemoteProperties: RemoteProperties{Url: srv.URL, Method: http.MethodGet}}
+	if err := (RemoteWorker{}).Work(context.Background(), j); err == nil {
+		t.Fatal("expected a 500 response to be reported as a failure")
+	}
+}
@@ -0,0 +1,52 @@
+// Package iso8601 implements parsing of ISO 8601 durations (e.g. "P1DT10M10S")
+// into time.Duration-compatible values, used to compute job delays and
+// recurrence intervals.
+package iso8601
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRegex = regexp.MustCompile(
+	`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// Duration represents a parsed ISO 8601 duration. Years and months are kept
+// separate from the fixed-length components because their real length
+// depends on the calendar date they're applied from.
+type Duration struct {
+	Years, Months, Days, Hours, Minutes, Seconds int
+}
+
+// FromString parses an ISO 8601 duration string such as "P1DT10M10S".
+func FromString(s string) (*Duration, error) {
+	matches := durationRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, fmt.Errorf("iso8601: invalid duration %q", s)
+	}
+
+	d := &Duration{}
+	fields := []*int{&d.Years, &d.Months, &d.Days, &d.Hours, &d.Minutes, &d.Seconds}
+	for i, field := range fields {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("iso8601: invalid duration %q: %w", s, err)
+		}
+		*field = n
+	}
+	return d, nil
+}
+
+// RelativeTo adds the duration to t, accounting for the variable length of
+// years and months.
+func (d *Duration) RelativeTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).
+		Add(time.Duration(d.Hours)*time.Hour +
+			time.Duration(d.Minutes)*time.Minute +
+			time.Duration(d.Seconds)*time.Second)
+}